@@ -19,6 +19,16 @@ const (
 	defaultTextSource  = TextSourceWords
 	defaultWordCount   = 50
 	defaultTimeSeconds = 30
+	defaultQuoteLength = "medium"
+	defaultCodeLang    = "go"
+	defaultPasteMode   = pasteModeIgnore
+)
+
+// PasteMode values controlling how a detected bracketed paste is handled
+// during a session; see consumeBracketedPaste.
+const (
+	pasteModeIgnore      = "ignore"       // drop pasted bytes, flash a warning
+	pasteModeCountErrors = "count-errors" // count pasted runes as raw errors
 )
 
 // Command-line flags
@@ -31,6 +41,15 @@ var (
 	textSourceShort  = flag.String("s", "", "Text source: words or sentences")
 	showVersion      = flag.Bool("version", false, "Print version information")
 	showVersionShort = flag.Bool("v", false, "Print version information")
+	showHistory      = flag.Bool("history", false, "Print aggregate stats from recorded session history and exit")
+	showStats        = flag.Bool("stats", false, "Alias for --history")
+	resetHistoryFlag = flag.Bool("reset-history", false, "Delete recorded session history and exit")
+	showHeatmap      = flag.Bool("heatmap", false, "Print a slow-key/bigram heatmap after each session")
+	quoteLength      = flag.String("length", "", "Quote length bucket for --source quotes: short, medium, or long")
+	codeLang         = flag.String("lang", "", "Language for --source code: go, python, or js")
+	filePath         = flag.String("path", "", "Path to a text file for --source file")
+	themeFlag        = flag.String("theme", "", "Color theme: default, dracula, gruvbox, solarized, nord, or a name from ~/.config/zootype/themes")
+	pasteMode        = flag.String("on-paste", "", "How to handle a detected bracketed paste: ignore (default) or count-errors")
 )
 
 // TextSource represents the type of text used for typing practice.
@@ -39,6 +58,16 @@ type TextSource string
 const (
 	TextSourceSentences TextSource = "sentences"
 	TextSourceWords     TextSource = "words"
+	TextSourceWeak      TextSource = "weak"
+	TextSourceQuotes    TextSource = "quotes"
+	TextSourceCode      TextSource = "code"
+	TextSourceFile      TextSource = "file"
+	// TextSourceCustom's text is served through providerRegistry like the
+	// other sources (customProvider, customtext.go), but entering it needs
+	// live keyboard input (promptForCustomText), which getSessionText's
+	// signature has no access to, so the prompt runs first in the
+	// custom-mode branch of run() (main.go).
+	TextSourceCustom TextSource = "custom"
 )
 
 // Config holds runtime configuration from CLI flags and defaults.
@@ -46,6 +75,13 @@ type Config struct {
 	TextSource  TextSource
 	WordCount   int
 	TimeSeconds int
+	ShowHeatmap bool
+	ThemeName   string
+	QuoteLength string // short, medium, or long; used by the quotes provider
+	CodeLang    string // go, python, or js; used by the code provider
+	FilePath    string // used by the file provider
+	PasteMode   string // ignore or count-errors; see consumeBracketedPaste
+	CustomText  string // entered via promptForCustomText; used by the custom provider
 }
 
 // loadConfig loads configuration from CLI flags and defaults.
@@ -57,10 +93,72 @@ func loadConfig() (Config, error) {
 		os.Exit(0)
 	}
 
+	if *resetHistoryFlag {
+		if err := resetHistory(); err != nil {
+			return Config{}, err
+		}
+		fmt.Println("History reset.")
+		os.Exit(0)
+	}
+
+	// A persisted ~/.config/zootype/config.toml supplies defaults, which
+	// flags below are free to override. Resolved before the --history/
+	// --stats early exit below so that output is themed too.
+	persisted, err := loadPersistedConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	themeName := persisted.ThemeName
+	if *themeFlag != "" {
+		themeName = *themeFlag
+	}
+	theme, err := loadTheme(themeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v, falling back to default theme\n", err)
+		theme = defaultTheme()
+	}
+
+	if *showHistory || *showStats {
+		if err := printHistoryStats(theme); err != nil {
+			return Config{}, err
+		}
+		os.Exit(0)
+	}
+
 	config := Config{
 		TextSource:  defaultTextSource,
 		WordCount:   defaultWordCount,
 		TimeSeconds: defaultTimeSeconds,
+		ShowHeatmap: *showHeatmap,
+		QuoteLength: defaultQuoteLength,
+		CodeLang:    defaultCodeLang,
+		PasteMode:   defaultPasteMode,
+		ThemeName:   themeName,
+	}
+
+	if persisted.TextSource != "" {
+		config.TextSource = persisted.TextSource
+	}
+	if persisted.WordCount > 0 {
+		config.WordCount = persisted.WordCount
+		config.TimeSeconds = 0
+	}
+	if persisted.TimeSeconds > 0 {
+		config.TimeSeconds = persisted.TimeSeconds
+	}
+
+	if *quoteLength != "" {
+		config.QuoteLength = *quoteLength
+	}
+	if *codeLang != "" {
+		config.CodeLang = *codeLang
+	}
+	if *filePath != "" {
+		config.FilePath = *filePath
+	}
+	if *pasteMode != "" {
+		config.PasteMode = *pasteMode
 	}
 
 	// Apply CLI flags
@@ -93,23 +191,14 @@ func loadConfig() (Config, error) {
 	return config, nil
 }
 
-// getSessionText generates practice text based on configured source.
+// getSessionText generates practice text using the TextProvider registered
+// for the configured source. See providers.go.
 func getSessionText(config Config) (string, error) {
-	switch config.TextSource {
-	case TextSourceSentences:
-		if config.TimeSeconds > 0 {
-			return generateInfiniteSentences(), nil
-		}
-		sentences := defaultSentences()
-		return sentences[rand.Intn(len(sentences))], nil
-	case TextSourceWords:
-		if config.TimeSeconds > 0 {
-			return generateInfiniteWordText()
-		}
-		return generateWordText(config.WordCount)
-	default:
+	provider, ok := providerRegistry[config.TextSource]
+	if !ok {
 		return "", fmt.Errorf("unknown text source: %s", config.TextSource)
 	}
+	return provider.Generate(config)
 }
 
 // generateWordText creates practice text by randomly selecting words.
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// csiToken identifies a recognized CSI (ESC [ ...) sequence. Shared by the
+// REPL's line editor (repl.go) and the custom-text editor (customtext.go)
+// so both get arrow/home/end/delete handling from one parser instead of
+// two slightly-different ones; the session event loop (session.go) uses
+// the same parser for the bracketed-paste markers.
+type csiToken int
+
+const (
+	csiNone csiToken = iota
+	csiUp
+	csiDown
+	csiLeft
+	csiRight
+	csiHome
+	csiEnd
+	csiDelete
+	csiPasteStart
+	csiPasteEnd
+)
+
+// bracketed-paste CSI parameter bytes (ESC [ 200 ~ starts a paste, ESC [ 201 ~ ends it).
+const (
+	pasteStartParams = "200"
+	pasteEndParams   = "201"
+)
+
+// readCSISequence reads the bytes following an ESC and splits a CSI sequence
+// (ESC [ <params> <final>) into its parameter digits and final byte, e.g.
+// "ESC [ 200 ~" yields params="200", final='~'. Returns ok=false for a lone
+// ESC, a non-CSI sequence, or one that times out before completing.
+func readCSISequence(keyChan <-chan keyEvent) (params string, final rune, ok bool) {
+	const maxParamLen = 8
+	timeout := time.After(escapeSeqTimeout)
+
+	var lead keyEvent
+	select {
+	case lead = <-keyChan:
+	case <-timeout:
+		return "", 0, false
+	}
+	if lead.IsCtrl || lead.Rune != '[' {
+		return "", 0, false
+	}
+
+	var digits strings.Builder
+	for i := 0; i < maxParamLen; i++ {
+		var e keyEvent
+		select {
+		case e = <-keyChan:
+		case <-timeout:
+			return "", 0, false
+		}
+		if e.IsCtrl {
+			return "", 0, false
+		}
+		if e.Rune >= '0' && e.Rune <= '9' {
+			digits.WriteRune(e.Rune)
+			continue
+		}
+		return digits.String(), e.Rune, true
+	}
+
+	return "", 0, false
+}
+
+// classifyCSI maps a parsed CSI sequence to the token it represents. CSI
+// sequences neither side cares about (function keys, modified arrows, ...)
+// classify as csiNone and are simply ignored by callers.
+func classifyCSI(params string, final rune) csiToken {
+	if params == "" {
+		switch final {
+		case 'A':
+			return csiUp
+		case 'B':
+			return csiDown
+		case 'C':
+			return csiRight
+		case 'D':
+			return csiLeft
+		case 'H':
+			return csiHome
+		case 'F':
+			return csiEnd
+		}
+		return csiNone
+	}
+
+	if final != '~' {
+		return csiNone
+	}
+
+	switch params {
+	case "3":
+		return csiDelete
+	case pasteStartParams:
+		return csiPasteStart
+	case pasteEndParams:
+		return csiPasteEnd
+	default:
+		return csiNone
+	}
+}
+
+// readCSIKey reads the bytes following an ESC and classifies the CSI
+// sequence they form into a token. Returns csiNone for a lone ESC, a
+// non-CSI sequence, one that times out before completing, or one neither
+// caller recognizes.
+func readCSIKey(keyChan <-chan keyEvent) csiToken {
+	params, final, ok := readCSISequence(keyChan)
+	if !ok {
+		return csiNone
+	}
+	return classifyCSI(params, final)
+}
@@ -0,0 +1,85 @@
+package main
+
+import "testing"
+
+func TestClassifyCSI(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		final  rune
+		want   csiToken
+	}{
+		{"up", "", 'A', csiUp},
+		{"down", "", 'B', csiDown},
+		{"right", "", 'C', csiRight},
+		{"left", "", 'D', csiLeft},
+		{"home", "", 'H', csiHome},
+		{"end", "", 'F', csiEnd},
+		{"unrecognized no-params final", "", 'Z', csiNone},
+		{"delete", "3", '~', csiDelete},
+		{"paste start", pasteStartParams, '~', csiPasteStart},
+		{"paste end", pasteEndParams, '~', csiPasteEnd},
+		{"unrecognized params", "99", '~', csiNone},
+		{"params with non-tilde final", "3", 'A', csiNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyCSI(tt.params, tt.final); got != tt.want {
+				t.Errorf("classifyCSI(%q, %q) = %v, want %v", tt.params, tt.final, got, tt.want)
+			}
+		})
+	}
+}
+
+// feedKeys returns a channel preloaded with the given runes, each as a
+// non-control keyEvent, for driving readCSISequence/readCSIKey in tests.
+func feedKeys(runes ...rune) <-chan keyEvent {
+	keyChan := make(chan keyEvent, len(runes))
+	for _, r := range runes {
+		keyChan <- keyEvent{Rune: r}
+	}
+	return keyChan
+}
+
+func TestReadCSISequence(t *testing.T) {
+	tests := []struct {
+		name       string
+		runes      []rune
+		wantParams string
+		wantFinal  rune
+		wantOK     bool
+	}{
+		{"arrow up", []rune{'[', 'A'}, "", 'A', true},
+		{"delete key", []rune{'[', '3', '~'}, "3", '~', true},
+		{"bracketed paste start", []rune{'[', '2', '0', '0', '~'}, "200", '~', true},
+		{"not a CSI lead byte", []rune{'O', 'A'}, "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, final, ok := readCSISequence(feedKeys(tt.runes...))
+			if ok != tt.wantOK || params != tt.wantParams || final != tt.wantFinal {
+				t.Errorf("readCSISequence(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					string(tt.runes), params, final, ok, tt.wantParams, tt.wantFinal, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReadCSISequenceTimesOutOnEmptyChannel(t *testing.T) {
+	keyChan := make(chan keyEvent)
+	_, _, ok := readCSISequence(keyChan)
+	if ok {
+		t.Error("readCSISequence on an empty channel should time out with ok=false")
+	}
+}
+
+func TestReadCSIKey(t *testing.T) {
+	if got := readCSIKey(feedKeys('[', 'D')); got != csiLeft {
+		t.Errorf("readCSIKey(left arrow) = %v, want csiLeft", got)
+	}
+	if got := readCSIKey(feedKeys('[', '2', '0', '1', '~')); got != csiPasteEnd {
+		t.Errorf("readCSIKey(paste end) = %v, want csiPasteEnd", got)
+	}
+}
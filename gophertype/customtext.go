@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxCustomTextHistory caps how many previously entered custom texts are
+// kept for up/down recall, oldest dropped first.
+const maxCustomTextHistory = 50
+
+// customProvider serves the text most recently entered via
+// promptForCustomText, stashed on Config by the custom-mode branch in run().
+type customProvider struct{}
+
+func (customProvider) Name() string          { return string(TextSourceCustom) }
+func (customProvider) SupportsInfinite() bool { return false }
+
+func (customProvider) Generate(config Config) (string, error) {
+	if config.CustomText == "" {
+		return "", fmt.Errorf("no custom text entered")
+	}
+	return config.CustomText, nil
+}
+
+func init() {
+	registerProvider(customProvider{})
+}
+
+// promptForCustomText renders a small in-terminal line editor, modeled on
+// golang.org/x/term's Terminal.ReadLine, for entering a custom passage
+// (a quote, a code snippet, foreign-language text) to drill on in place of
+// a random word list. Supports left/right/home/end cursor movement,
+// backspace/delete, ctrl-u (kill line), ctrl-w (kill word), and up/down
+// recall across previously entered texts. Enter submits and records the
+// text to history; Esc or Ctrl-C cancels, returning cancelled=true.
+func promptForCustomText(keyChan <-chan keyEvent, theme Theme) (text string, cancelled bool, err error) {
+	history, err := loadCustomTextHistory()
+	if err != nil {
+		return "", false, err
+	}
+
+	var line []rune
+	cursor := 0
+	historyPos := len(history)
+
+	render := func() {
+		fmt.Print(ansiClearScreen + ansiCursorHome)
+		fmt.Printf("%sEnter custom practice text%s (enter to submit, esc to cancel, up/down for history):\r\n\r\n", theme.Blue, theme.Reset)
+		fmt.Print(string(line[:cursor]))
+		fmt.Print(theme.Yellow + ansiBold + "|" + theme.Reset)
+		fmt.Print(string(line[cursor:]))
+	}
+	render()
+
+	for {
+		key := <-keyChan
+
+		if key.IsCtrl {
+			switch key.Control {
+			case keyCtrlC:
+				return "", true, nil
+
+			case keyEnter, keyReturn:
+				entered := strings.TrimSpace(string(line))
+				if entered == "" {
+					continue
+				}
+				if err := appendCustomTextHistory(entered); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: failed to record custom text history: %v\n", err)
+				}
+				return entered, false, nil
+
+			case keyDelete, keyBackspace:
+				if cursor > 0 {
+					line = append(line[:cursor-1], line[cursor:]...)
+					cursor--
+				}
+
+			case keyEscape:
+				switch readCSIKey(keyChan) {
+				case csiUp:
+					if historyPos > 0 {
+						historyPos--
+						line = []rune(history[historyPos])
+						cursor = len(line)
+					}
+				case csiDown:
+					if historyPos < len(history)-1 {
+						historyPos++
+						line = []rune(history[historyPos])
+					} else {
+						historyPos = len(history)
+						line = nil
+					}
+					cursor = len(line)
+				case csiLeft:
+					if cursor > 0 {
+						cursor--
+					}
+				case csiRight:
+					if cursor < len(line) {
+						cursor++
+					}
+				case csiHome:
+					cursor = 0
+				case csiEnd:
+					cursor = len(line)
+				case csiDelete:
+					if cursor < len(line) {
+						line = append(line[:cursor], line[cursor+1:]...)
+					}
+				case csiNone:
+					// A lone Esc (no CSI sequence followed), or a CSI
+					// sequence readCSIKey does not recognize, cancels.
+					return "", true, nil
+				}
+			}
+			render()
+			continue
+		}
+
+		switch key.Rune {
+		case 21: // Ctrl-U: kill from the start of the line to the cursor
+			line = line[cursor:]
+			cursor = 0
+
+		case 23: // Ctrl-W: kill the word immediately before the cursor
+			line, cursor = killPreviousWord(line, cursor)
+
+		default:
+			if key.Rune >= 32 {
+				line = append(line[:cursor], append([]rune{key.Rune}, line[cursor:]...)...)
+				cursor++
+			}
+		}
+		render()
+	}
+}
+
+// killPreviousWord removes the word (and any run of spaces) immediately
+// before the cursor, mirroring readline's Ctrl-W.
+func killPreviousWord(line []rune, cursor int) ([]rune, int) {
+	i := cursor
+	for i > 0 && line[i-1] == ' ' {
+		i--
+	}
+	for i > 0 && line[i-1] != ' ' {
+		i--
+	}
+	return append(line[:i], line[cursor:]...), i
+}
+
+// customTextHistoryFile returns the path to the persisted list of
+// previously entered custom texts, creating its parent directory if
+// necessary. Honors $XDG_STATE_HOME, falling back to ~/.local/state per
+// the XDG Base Directory spec.
+func customTextHistoryFile() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	dir = filepath.Join(dir, "zootype")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	return filepath.Join(dir, "history"), nil
+}
+
+// loadCustomTextHistory reads previously entered custom texts, one per
+// line, oldest first. A missing file is treated as empty history.
+func loadCustomTextHistory() ([]string, error) {
+	path, err := customTextHistoryFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read custom text history: %w", err)
+	}
+
+	var entries []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries, nil
+}
+
+// appendCustomTextHistory records a newly entered custom text, capped at
+// maxCustomTextHistory most-recent entries.
+func appendCustomTextHistory(text string) error {
+	entries, err := loadCustomTextHistory()
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, text)
+	if len(entries) > maxCustomTextHistory {
+		entries = entries[len(entries)-maxCustomTextHistory:]
+	}
+
+	path, err := customTextHistoryFile()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(strings.Join(entries, "\n")+"\n"), 0o644)
+}
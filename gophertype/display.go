@@ -7,20 +7,22 @@ import (
 )
 
 const (
-	ansiReset            = "\033[0m"
-	ansiBold             = "\033[1m"
-	ansiRed              = "\033[31m"
-	ansiGreen            = "\033[32m"
-	ansiYellow           = "\033[33m"
-	ansiBlue             = "\033[34m"
-	ansiClearToEOL       = "\033[K"
-	ansiClearScreen      = "\033[2J"
-	ansiCursorUp         = "\033[A"
-	ansiCursorHome       = "\033[H"
-	ansiAltScreenEnable  = "\033[?1049h"
-	ansiAltScreenDisable = "\033[?1049l"
-	ansiCursorHide       = "\033[?25l"
-	ansiCursorShow       = "\033[?25h"
+	ansiReset             = "\033[0m"
+	ansiBold              = "\033[1m"
+	ansiRed               = "\033[31m"
+	ansiGreen             = "\033[32m"
+	ansiYellow            = "\033[33m"
+	ansiBlue              = "\033[34m"
+	ansiClearToEOL        = "\033[K"
+	ansiClearScreen       = "\033[2J"
+	ansiCursorUp          = "\033[A"
+	ansiCursorHome        = "\033[H"
+	ansiAltScreenEnable   = "\033[?1049h"
+	ansiAltScreenDisable  = "\033[?1049l"
+	ansiCursorHide        = "\033[?25l"
+	ansiCursorShow        = "\033[?25h"
+	ansiBracketedPasteOn  = "\033[?2004h"
+	ansiBracketedPasteOff = "\033[?2004l"
 
 	maxVisibleLines = 3
 	cursorWidth     = 1
@@ -60,6 +62,8 @@ func displayProgress(state *TypingState) {
 	if state.isTimedMode {
 		state.lastLineCount++ // Account for timer line
 	}
+
+	sampleProgress(state)
 }
 
 // buildClearSequence generates cursor positioning commands to prepare for redraw.
@@ -91,12 +95,15 @@ func formatTimer(state *TypingState) string {
 		seconds++
 	}
 
-	return fmt.Sprintf("%s%d%s%s\r\n", ansiBlue, seconds, ansiReset, ansiClearToEOL)
+	return fmt.Sprintf("%s%d%s%s\r\n", state.theme.Blue, seconds, state.theme.Reset, ansiClearToEOL)
 }
 
 // wrapTextToLines splits text into display lines with word-boundary wrapping.
 // Tracks cursor position and maps each display position back to original text index.
-func wrapTextToLines(text string, cursorPos, lineWidth int) []wrappedLine {
+// Positions (cursorPosition, charIndices) are rune indices into the line's
+// content, not display columns; the wrap decision itself is width-aware via
+// displayWidth, since a wide CJK glyph occupies two terminal columns.
+func wrapTextToLines(text []rune, cursorPos, lineWidth int) []wrappedLine {
 	words := splitIntoWords(text)
 	var lines []wrappedLine
 	currentLine := wrappedLine{}
@@ -104,12 +111,12 @@ func wrapTextToLines(text string, cursorPos, lineWidth int) []wrappedLine {
 
 	for _, word := range words {
 		// Wrap to new line if word doesn't fit
-		if len(currentLine.content) > 0 && len(currentLine.content)+len(word) > lineWidth {
+		if len(currentLine.content) > 0 && displayWidth(currentLine.content)+displayWidth(word) > lineWidth {
 			lines = append(lines, currentLine)
 			currentLine = wrappedLine{}
 
 			// Skip leading spaces on new lines
-			if word == " " {
+			if len(word) == 1 && word[0] == ' ' {
 				textIndex += len(word)
 				continue
 			}
@@ -141,24 +148,59 @@ func wrapTextToLines(text string, cursorPos, lineWidth int) []wrappedLine {
 	return lines
 }
 
+// displayWidth returns the number of terminal columns a rune sequence
+// occupies, accounting for wide (e.g. CJK) and zero-width (combining mark)
+// runes - see runeWidth.
+func displayWidth(runes []rune) int {
+	width := 0
+	for _, r := range runes {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth returns the terminal column width of a single rune: 0 for
+// zero-width combining marks, 2 for wide East Asian characters, 1 otherwise.
+// This is a minimal approximation of Unicode East Asian Width (UAX #11)
+// covering the common CJK/emoji ranges, not a full implementation.
+func runeWidth(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case r >= 0x0300 && r <= 0x036F: // combining diacritical marks
+		return 0
+	case r >= 0x1100 && r <= 0x115F, // Hangul Jamo
+		r >= 0x2E80 && r <= 0xA4CF, // CJK radicals through Yi
+		r >= 0xAC00 && r <= 0xD7A3, // Hangul syllables
+		r >= 0xF900 && r <= 0xFAFF, // CJK compatibility ideographs
+		r >= 0xFF00 && r <= 0xFF60, // fullwidth forms
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x1F300 && r <= 0x1FAFF, // emoji and pictographs
+		r >= 0x20000 && r <= 0x3FFFD: // CJK unified ideographs extensions
+		return 2
+	default:
+		return 1
+	}
+}
+
 // splitIntoWords tokenizes text into words and spaces for word-boundary wrapping.
-func splitIntoWords(text string) []string {
-	var words []string
-	currentWord := ""
+func splitIntoWords(text []rune) [][]rune {
+	var words [][]rune
+	var currentWord []rune
 
 	for _, char := range text {
 		if char == ' ' {
-			if currentWord != "" {
+			if len(currentWord) > 0 {
 				words = append(words, currentWord)
-				currentWord = ""
+				currentWord = nil
 			}
-			words = append(words, " ")
+			words = append(words, []rune{' '})
 		} else {
-			currentWord += string(char)
+			currentWord = append(currentWord, char)
 		}
 	}
 
-	if currentWord != "" {
+	if len(currentWord) > 0 {
 		words = append(words, currentWord)
 	}
 
@@ -199,11 +241,13 @@ func calculateVisibleWindow(lines []wrappedLine, maxLines int) (start, end int)
 func renderLines(lines []wrappedLine, state *TypingState) string {
 	var output strings.Builder
 
+	theme := state.theme
+
 	for lineIdx, line := range lines {
 		for pos, char := range line.content {
 			// Show cursor before this character if applicable
 			if line.hasCursor && pos == line.cursorPosition {
-				output.WriteString(ansiYellow + ansiBold + "|" + ansiReset)
+				output.WriteString(theme.Yellow + theme.Bold + "|" + theme.Reset)
 			}
 
 			origIdx := line.charIndices[pos]
@@ -211,12 +255,12 @@ func renderLines(lines []wrappedLine, state *TypingState) string {
 			if origIdx < state.position {
 				// Character has been typed - color by correctness
 				if state.charCorrectness[origIdx] {
-					output.WriteString(ansiGreen)
+					output.WriteString(theme.Green)
 				} else {
-					output.WriteString(ansiRed)
+					output.WriteString(theme.Red)
 				}
 				output.WriteRune(char)
-				output.WriteString(ansiReset)
+				output.WriteString(theme.Reset)
 			} else {
 				// Not yet typed - default color
 				output.WriteRune(char)
@@ -225,7 +269,7 @@ func renderLines(lines []wrappedLine, state *TypingState) string {
 
 		// Show cursor at end of line if applicable
 		if line.hasCursor && line.cursorPosition >= len(line.content) {
-			output.WriteString(ansiYellow + ansiBold + "|" + ansiReset)
+			output.WriteString(theme.Yellow + theme.Bold + "|" + theme.Reset)
 		}
 
 		output.WriteString(ansiClearToEOL)
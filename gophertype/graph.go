@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	graphSampleInterval = time.Second
+	graphHeight         = 8 // character rows for the WPM chart
+	accuracyHeight      = 3 // character rows for the accuracy overlay
+	brailleRows         = 4 // dot rows per braille cell
+	brailleCols         = 2 // dot columns per braille cell
+)
+
+// brailleDotBits maps a (sub-row, sub-col) position within a braille cell to
+// its bit in the U+2800 braille pattern block.
+var brailleDotBits = [brailleRows][brailleCols]int{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// sampleProgress records a ~1Hz snapshot of session progress for the
+// post-session graph. Called from displayProgress, which is itself called
+// far more often than 1Hz, so samples are throttled here.
+func sampleProgress(state *TypingState) {
+	if state.startTime.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	if !state.lastSampleTime.IsZero() && now.Sub(state.lastSampleTime) < graphSampleInterval {
+		return
+	}
+	state.lastSampleTime = now
+
+	state.samples = append(state.samples, progressSample{
+		elapsed:      now.Sub(state.startTime),
+		correctChars: countCorrectChars(state),
+		errors:       state.errors,
+	})
+}
+
+// renderSessionGraph draws a compact braille-dot WPM line chart with an
+// accuracy overlay and error markers, scaled to the terminal width. Returns
+// "" if there isn't enough data to plot.
+func renderSessionGraph(state *TypingState) string {
+	if len(state.samples) < 2 {
+		return ""
+	}
+
+	width := state.terminalWidth
+	if width < 20 {
+		width = 20
+	}
+
+	wpmSeries, accuracySeries, errorMarks := sampleSeries(state.samples)
+
+	theme := state.theme
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("%sWPM%s\r\n", theme.Blue, theme.Reset))
+	out.WriteString(renderBrailleSeries(wpmSeries, width, graphHeight, theme.Green, theme.Reset))
+	out.WriteString(renderAxisAnnotation(wpmSeries))
+
+	out.WriteString(fmt.Sprintf("%sAccuracy%s\r\n", theme.Blue, theme.Reset))
+	out.WriteString(renderBrailleSeries(accuracySeries, width, accuracyHeight, theme.Blue, theme.Reset))
+
+	out.WriteString(renderErrorMarkers(errorMarks, width, theme))
+
+	return out.String()
+}
+
+// sampleSeries derives the WPM-over-time and accuracy-over-time series, plus
+// which samples saw new errors, from the raw progress samples.
+func sampleSeries(samples []progressSample) (wpm, accuracy []float64, errorMarks []bool) {
+	wpm = make([]float64, len(samples))
+	accuracy = make([]float64, len(samples))
+	errorMarks = make([]bool, len(samples))
+
+	prevErrors := 0
+	for i, s := range samples {
+		wpm[i] = calculateWPM(s.correctChars, s.elapsed)
+
+		typed := s.correctChars + s.errors
+		accuracy[i] = calculateAccuracy(typed, s.errors)
+
+		errorMarks[i] = s.errors > prevErrors
+		prevErrors = s.errors
+	}
+
+	return wpm, accuracy, errorMarks
+}
+
+// renderBrailleSeries scales a series into a fixed-width braille-dot canvas.
+func renderBrailleSeries(series []float64, width, height int, color, reset string) string {
+	fineCols := width * brailleCols
+	fineRows := height * brailleRows
+
+	minVal, maxVal := series[0], series[0]
+	for _, v := range series {
+		minVal = math.Min(minVal, v)
+		maxVal = math.Max(maxVal, v)
+	}
+	if maxVal == minVal {
+		maxVal = minVal + 1
+	}
+
+	grid := make([][]bool, fineRows)
+	for i := range grid {
+		grid[i] = make([]bool, fineCols)
+	}
+
+	for x := 0; x < fineCols; x++ {
+		t := float64(x) / float64(fineCols-1)
+		idx := int(t * float64(len(series)-1))
+		normalized := (series[idx] - minVal) / (maxVal - minVal)
+		y := fineRows - 1 - int(normalized*float64(fineRows-1))
+		if y >= 0 && y < fineRows {
+			grid[y][x] = true
+		}
+	}
+
+	var out strings.Builder
+	for row := 0; row < height; row++ {
+		out.WriteString(color)
+		for col := 0; col < width; col++ {
+			bits := 0
+			for subRow := 0; subRow < brailleRows; subRow++ {
+				for subCol := 0; subCol < brailleCols; subCol++ {
+					fr := row*brailleRows + subRow
+					fc := col*brailleCols + subCol
+					if grid[fr][fc] {
+						bits |= brailleDotBits[subRow][subCol]
+					}
+				}
+			}
+			out.WriteRune(rune(0x2800 + bits))
+		}
+		out.WriteString(reset + "\r\n")
+	}
+
+	return out.String()
+}
+
+// renderErrorMarkers renders a row of tick marks under columns where an
+// error occurred, scaled to the same width as the graphs above it.
+func renderErrorMarkers(errorMarks []bool, width int, theme Theme) string {
+	cells := make([]bool, width)
+	for i, hit := range errorMarks {
+		if !hit {
+			continue
+		}
+		col := i * width / len(errorMarks)
+		if col >= width {
+			col = width - 1
+		}
+		cells[col] = true
+	}
+
+	var out strings.Builder
+	out.WriteString(theme.Red)
+	for _, hit := range cells {
+		if hit {
+			out.WriteRune('^')
+		} else {
+			out.WriteRune(' ')
+		}
+	}
+	out.WriteString(theme.Reset + "\r\n")
+
+	return out.String()
+}
+
+// renderAxisAnnotation prints min/max/avg labels under the WPM chart.
+func renderAxisAnnotation(wpmSeries []float64) string {
+	minVal, maxVal, total := wpmSeries[0], wpmSeries[0], 0.0
+	for _, v := range wpmSeries {
+		minVal = math.Min(minVal, v)
+		maxVal = math.Max(maxVal, v)
+		total += v
+	}
+	avg := total / float64(len(wpmSeries))
+
+	return fmt.Sprintf("min %.0f  avg %.0f  max %.0f wpm\r\n", minVal, avg, maxVal)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dwellStats summarizes how long a key or bigram took to reach, across a session.
+type dwellStats struct {
+	key    string
+	mean   time.Duration
+	median time.Duration
+	n      int
+}
+
+// computeCharDwellStats returns per-character dwell-time stats (time elapsed
+// since the previous keystroke), sorted slowest-median-first.
+func computeCharDwellStats(events []keystrokeEvent) []dwellStats {
+	samples := make(map[string][]time.Duration)
+
+	for i := 1; i < len(events); i++ {
+		delta := events[i].at.Sub(events[i-1].at)
+		key := strings.ToLower(string(events[i].char))
+		samples[key] = append(samples[key], delta)
+	}
+
+	return sortedDwellStats(samples)
+}
+
+// computeBigramDwellStats returns per-bigram dwell-time stats, keyed by the
+// two-character sequence (previous char followed by current char).
+func computeBigramDwellStats(events []keystrokeEvent) []dwellStats {
+	samples := make(map[string][]time.Duration)
+
+	for i := 1; i < len(events); i++ {
+		delta := events[i].at.Sub(events[i-1].at)
+		key := strings.ToLower(string(events[i-1].char) + string(events[i].char))
+		samples[key] = append(samples[key], delta)
+	}
+
+	return sortedDwellStats(samples)
+}
+
+func sortedDwellStats(samples map[string][]time.Duration) []dwellStats {
+	var stats []dwellStats
+	for key, durations := range samples {
+		stats = append(stats, dwellStats{
+			key:    key,
+			mean:   meanDuration(durations),
+			median: medianDuration(durations),
+			n:      len(durations),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].median > stats[j].median
+	})
+
+	return stats
+}
+
+func meanDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// printHeatmap renders a color-scaled table of the slowest letters and
+// bigrams typed during the session, using the session's theme.
+func printHeatmap(state *TypingState) {
+	const rowLimit = 10
+
+	charStats := filterLetters(computeCharDwellStats(state.keystrokes))
+	bigramStats := filterLetterBigrams(computeBigramDwellStats(state.keystrokes))
+
+	fmt.Printf("\r\n%sSlowest keys:%s\r\n", state.theme.Blue, state.theme.Reset)
+	printDwellRow(charStats, rowLimit, state.theme)
+
+	fmt.Printf("\r\n%sSlowest bigrams:%s\r\n", state.theme.Blue, state.theme.Reset)
+	printDwellRow(bigramStats, rowLimit, state.theme)
+}
+
+func printDwellRow(stats []dwellStats, limit int, theme Theme) {
+	if len(stats) == 0 {
+		fmt.Print("  (not enough data)\r\n")
+		return
+	}
+	if limit > len(stats) {
+		limit = len(stats)
+	}
+
+	for i, s := range stats[:limit] {
+		fmt.Printf("  %s%-4s %5dms%s", heatColor(i, limit, theme), s.key, s.median.Milliseconds(), theme.Reset)
+		if (i+1)%5 == 0 {
+			fmt.Print("\r\n")
+		}
+	}
+	if limit%5 != 0 {
+		fmt.Print("\r\n")
+	}
+}
+
+// heatColor buckets a slowest-first rank into red (slowest third), yellow
+// (middle third), or green (fastest third).
+func heatColor(rank, total int, theme Theme) string {
+	third := total / 3
+	switch {
+	case rank < third:
+		return theme.Red
+	case rank < 2*third:
+		return theme.Yellow
+	default:
+		return theme.Green
+	}
+}
+
+func filterLetters(stats []dwellStats) []dwellStats {
+	var out []dwellStats
+	for _, s := range stats {
+		if len(s.key) == 1 && isLowerLetter(s.key[0]) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func filterLetterBigrams(stats []dwellStats) []dwellStats {
+	var out []dwellStats
+	for _, s := range stats {
+		if len(s.key) == 2 && isLowerLetter(s.key[0]) && isLowerLetter(s.key[1]) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func isLowerLetter(b byte) bool {
+	return b >= 'a' && b <= 'z'
+}
+
+// slowCharsFromHistory aggregates the slow-character rankings recorded
+// across past sessions into a single weighted set, used to bias word
+// selection in weak-key practice mode.
+func slowCharsFromHistory(entries []HistoryEntry) map[byte]int {
+	weights := make(map[byte]int)
+
+	for _, entry := range entries {
+		for rank, key := range entry.SlowChars {
+			if len(key) != 1 {
+				continue
+			}
+			weights[key[0]] += len(entry.SlowChars) - rank
+		}
+	}
+
+	return weights
+}
+
+// generateWeakWordText builds practice text biased toward words containing
+// the user's historically slowest characters.
+func generateWeakWordText(count int) (string, error) {
+	words, err := loadTopWords()
+	if err != nil {
+		return "", err
+	}
+	if len(words) == 0 {
+		return "", fmt.Errorf("no words available")
+	}
+
+	history, err := loadHistory()
+	if err != nil {
+		return "", err
+	}
+	weights := slowCharsFromHistory(history)
+
+	weak := wordsContainingAny(words, weights)
+	if len(weak) == 0 {
+		weak = words
+	}
+
+	selectedWords := make([]string, count)
+	for i := 0; i < count; i++ {
+		// Bias towards weak words but keep some variety from the full list.
+		if len(weak) > 0 && rand.Intn(10) < 7 {
+			selectedWords[i] = weak[rand.Intn(len(weak))]
+		} else {
+			selectedWords[i] = words[rand.Intn(len(words))]
+		}
+	}
+
+	return strings.Join(selectedWords, " "), nil
+}
+
+func wordsContainingAny(words []string, weights map[byte]int) []string {
+	if len(weights) == 0 {
+		return nil
+	}
+
+	var out []string
+	for _, word := range words {
+		for i := 0; i < len(word); i++ {
+			if _, ok := weights[word[i]]; ok {
+				out = append(out, word)
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeanDuration(t *testing.T) {
+	durations := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	if got := meanDuration(durations); got != 200*time.Millisecond {
+		t.Errorf("meanDuration(%v) = %v, want 200ms", durations, got)
+	}
+	if got := meanDuration(nil); got != 0 {
+		t.Errorf("meanDuration(nil) = %v, want 0", got)
+	}
+}
+
+func TestMedianDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		want      time.Duration
+	}{
+		{"empty", nil, 0},
+		{"odd count", []time.Duration{30 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond}, 20 * time.Millisecond},
+		{"even count averages the middle two", []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond, 40 * time.Millisecond}, 25 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := medianDuration(tt.durations); got != tt.want {
+				t.Errorf("medianDuration(%v) = %v, want %v", tt.durations, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeCharDwellStatsSortsSlowestFirst(t *testing.T) {
+	base := time.Now()
+	events := []keystrokeEvent{
+		{char: 'a', at: base},
+		{char: 'b', at: base.Add(10 * time.Millisecond)},  // b dwell: 10ms
+		{char: 'a', at: base.Add(110 * time.Millisecond)}, // a dwell: 100ms
+	}
+
+	stats := computeCharDwellStats(events)
+	if len(stats) != 2 {
+		t.Fatalf("computeCharDwellStats returned %d keys, want 2", len(stats))
+	}
+	if stats[0].key != "a" || stats[0].median != 100*time.Millisecond {
+		t.Errorf("slowest key = %+v, want key=a median=100ms", stats[0])
+	}
+	if stats[1].key != "b" || stats[1].median != 10*time.Millisecond {
+		t.Errorf("second key = %+v, want key=b median=10ms", stats[1])
+	}
+}
+
+func TestHeatColorBucketsByRank(t *testing.T) {
+	theme := defaultTheme()
+	tests := []struct {
+		rank, total int
+		want        string
+	}{
+		{0, 9, theme.Red},
+		{3, 9, theme.Yellow},
+		{8, 9, theme.Green},
+	}
+
+	for _, tt := range tests {
+		if got := heatColor(tt.rank, tt.total, theme); got != tt.want {
+			t.Errorf("heatColor(%d, %d) = %q, want %q", tt.rank, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestFilterLetters(t *testing.T) {
+	stats := []dwellStats{{key: "a"}, {key: "1"}, {key: "ab"}, {key: "z"}}
+	got := filterLetters(stats)
+	if len(got) != 2 || got[0].key != "a" || got[1].key != "z" {
+		t.Errorf("filterLetters(%v) = %v, want only single-letter keys a, z", stats, got)
+	}
+}
+
+func TestFilterLetterBigrams(t *testing.T) {
+	stats := []dwellStats{{key: "ab"}, {key: "a1"}, {key: "a"}, {key: "zz"}}
+	got := filterLetterBigrams(stats)
+	if len(got) != 2 || got[0].key != "ab" || got[1].key != "zz" {
+		t.Errorf("filterLetterBigrams(%v) = %v, want only two-letter keys ab, zz", stats, got)
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// historyFileName is the name of the JSONL file each session is appended to.
+const historyFileName = "history.jsonl"
+
+// HistoryEntry is a single completed session recorded to the history file.
+type HistoryEntry struct {
+	Timestamp      time.Time  `json:"timestamp"`
+	TextSource     TextSource `json:"text_source"`
+	TextHash       string     `json:"text_hash"`
+	WPM            float64    `json:"wpm"`
+	RawWPM         float64    `json:"raw_wpm"`
+	Accuracy       float64    `json:"accuracy"`
+	DurationMillis int64      `json:"duration_millis"`
+	Errors         int        `json:"errors"`
+	BackspaceCount int        `json:"backspace_count"`
+	SlowChars      []string   `json:"slow_chars,omitempty"` // Slowest-first letters by median dwell time
+}
+
+// historyFilePath returns the location of the persistent history file,
+// creating its parent directory if necessary.
+func historyFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+
+	dir := filepath.Join(home, ".zootype")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	return filepath.Join(dir, historyFileName), nil
+}
+
+// hashText returns a short hex digest identifying a target text, so repeated
+// practice of the same passage can be correlated across sessions.
+func hashText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// recordSessionHistory persists a completed session's results, logging (but
+// not failing the session over) any write error.
+func recordSessionHistory(state *TypingState, results *Results) {
+	if err := recordHistoryEntry(state, results); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to record history: %v\n", err)
+	}
+}
+
+// recordHistoryEntry appends a completed session's results to the history file.
+func recordHistoryEntry(state *TypingState, results *Results) error {
+	path, err := historyFilePath()
+	if err != nil {
+		return err
+	}
+
+	entry := HistoryEntry{
+		Timestamp:      time.Now(),
+		TextSource:     state.config.TextSource,
+		TextHash:       hashText(string(state.sessionText)),
+		WPM:            results.WPM,
+		RawWPM:         calculateWPM(state.rawKeystrokes, results.Duration),
+		Accuracy:       results.Accuracy,
+		DurationMillis: results.Duration.Milliseconds(),
+		Errors:         results.Errors,
+		BackspaceCount: results.BackspaceCount,
+		SlowChars:      slowestChars(state, 5),
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	if _, err := fmt.Fprintln(f, string(encoded)); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+
+	return nil
+}
+
+// slowestChars returns up to n single-character keys, slowest-median-first,
+// for biasing future weak-key practice sessions.
+func slowestChars(state *TypingState, n int) []string {
+	stats := filterLetters(computeCharDwellStats(state.keystrokes))
+	if len(stats) > n {
+		stats = stats[:n]
+	}
+
+	keys := make([]string, len(stats))
+	for i, s := range stats {
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// loadHistory reads every recorded session from the history file, oldest first.
+// A missing file is treated as empty history rather than an error.
+func loadHistory() ([]HistoryEntry, error) {
+	path, err := historyFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry HistoryEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse history entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	return entries, nil
+}
+
+// resetHistory deletes the persistent history file.
+func resetHistory() error {
+	path, err := historyFilePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove history file: %w", err)
+	}
+
+	return nil
+}
+
+// printHistoryStats prints aggregate metrics across all recorded sessions:
+// the all-time best WPM, a rolling average over the most recent sessions,
+// and WPM percentiles.
+func printHistoryStats(theme Theme) error {
+	entries, err := loadHistory()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No history recorded yet.")
+		return nil
+	}
+
+	const rollingWindow = 10
+
+	best := entries[0].WPM
+	for _, entry := range entries {
+		if entry.WPM > best {
+			best = entry.WPM
+		}
+	}
+
+	rollingStart := 0
+	if len(entries) > rollingWindow {
+		rollingStart = len(entries) - rollingWindow
+	}
+	rollingAvg := averageWPM(entries[rollingStart:])
+
+	fmt.Printf("%sHistory (%d sessions):%s\n", theme.Blue, len(entries), theme.Reset)
+	fmt.Printf("Best WPM:          %.1f\n", best)
+	fmt.Printf("Rolling %d-avg WPM: %.1f\n", rollingWindow, rollingAvg)
+	fmt.Printf("WPM p50:           %.1f\n", wpmPercentile(entries, 50))
+	fmt.Printf("WPM p90:           %.1f\n", wpmPercentile(entries, 90))
+	fmt.Printf("WPM p99:           %.1f\n", wpmPercentile(entries, 99))
+
+	return nil
+}
+
+func averageWPM(entries []HistoryEntry) float64 {
+	if len(entries) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, entry := range entries {
+		total += entry.WPM
+	}
+	return total / float64(len(entries))
+}
+
+// wpmPercentile returns the WPM value at the given percentile (0-100) using
+// linear interpolation between the two closest ranks of a sorted copy of
+// entries.
+func wpmPercentile(entries []HistoryEntry, percentile float64) float64 {
+	sorted := make([]float64, len(entries))
+	for i, entry := range entries {
+		sorted[i] = entry.WPM
+	}
+	sort.Float64s(sorted)
+
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower := int(rank)
+	upper := lower + 1
+	if upper >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+
+	frac := rank - float64(lower)
+	return sorted[lower] + frac*(sorted[upper]-sorted[lower])
+}
@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+func wpmEntries(wpms ...float64) []HistoryEntry {
+	entries := make([]HistoryEntry, len(wpms))
+	for i, wpm := range wpms {
+		entries[i] = HistoryEntry{WPM: wpm}
+	}
+	return entries
+}
+
+func TestAverageWPM(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []HistoryEntry
+		want    float64
+	}{
+		{"empty", nil, 0},
+		{"single", wpmEntries(42), 42},
+		{"several", wpmEntries(40, 50, 60), 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := averageWPM(tt.entries); got != tt.want {
+				t.Errorf("averageWPM(%v) = %v, want %v", tt.entries, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWpmPercentile(t *testing.T) {
+	entries := wpmEntries(10, 20, 30, 40, 50)
+
+	tests := []struct {
+		name       string
+		percentile float64
+		want       float64
+	}{
+		{"p0 is the minimum", 0, 10},
+		{"p50 is the median", 50, 30},
+		{"p100 is the maximum", 100, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wpmPercentile(entries, tt.percentile); got != tt.want {
+				t.Errorf("wpmPercentile(entries, %v) = %v, want %v", tt.percentile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWpmPercentileSingleEntry(t *testing.T) {
+	entries := wpmEntries(33)
+	if got := wpmPercentile(entries, 90); got != 33 {
+		t.Errorf("wpmPercentile(single entry, 90) = %v, want 33", got)
+	}
+}
+
+func TestWpmPercentileUnsorted(t *testing.T) {
+	entries := wpmEntries(50, 10, 30)
+	if got := wpmPercentile(entries, 50); got != 30 {
+		t.Errorf("wpmPercentile(unsorted entries, 50) = %v, want 30 (median)", got)
+	}
+}
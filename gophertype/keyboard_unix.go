@@ -0,0 +1,67 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// setupTerminal enables alternate screen buffer and raw mode for character-by-character input.
+// Returns a restore function that must be deferred.
+func setupTerminal() (func(), error) {
+	fmt.Print(ansiAltScreenEnable + ansiCursorHide + ansiBracketedPasteOn)
+
+	stdinFd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(stdinFd)
+	if err != nil {
+		fmt.Print(ansiBracketedPasteOff + ansiCursorShow + ansiAltScreenDisable)
+		return nil, err
+	}
+
+	restore := func() {
+		_ = term.Restore(stdinFd, oldState)
+		fmt.Print(ansiBracketedPasteOff + ansiCursorShow + ansiAltScreenDisable)
+		fmt.Print("\r\n")
+	}
+
+	return restore, nil
+}
+
+// startKeyboardReader spawns goroutines that read keyboard input for the
+// duration of the program, assembling complete UTF-8 sequences from raw
+// bytes before delivering them as runes, and flagging recognized control
+// bytes separately so callers never have to re-decode them.
+func startKeyboardReader() <-chan keyEvent {
+	rawBytes := make(chan byte)
+	events := make(chan keyEvent)
+
+	go func() {
+		defer close(rawBytes)
+		buf := make([]byte, 1)
+		for {
+			if _, err := os.Stdin.Read(buf); err != nil {
+				return
+			}
+			rawBytes <- buf[0]
+		}
+	}()
+
+	go func() {
+		for b := range rawBytes {
+			switch {
+			case isControlByte(b):
+				events <- keyEvent{Control: b, IsCtrl: true}
+			case b < utf8.RuneSelf:
+				events <- keyEvent{Rune: rune(b)}
+			default:
+				events <- keyEvent{Rune: decodeMultibyteRune(b, rawBytes)}
+			}
+		}
+	}()
+
+	return events
+}
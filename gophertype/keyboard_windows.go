@@ -0,0 +1,154 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ReadConsoleInputW isn't wrapped by x/sys/windows, so it's loaded directly
+// the way other console-aware Go programs do.
+var (
+	kernel32              = windows.NewLazySystemDLL("kernel32.dll")
+	procReadConsoleInputW = kernel32.NewProc("ReadConsoleInputW")
+)
+
+const keyEventType = 1 // INPUT_RECORD.EventType for KEY_EVENT
+
+// keyEventRecord mirrors the KEY_EVENT_RECORD member of Windows'
+// INPUT_RECORD union; startKeyboardReader reads only KeyDown and
+// UnicodeChar.
+type keyEventRecord struct {
+	KeyDown         int32
+	RepeatCount     uint16
+	VirtualKeyCode  uint16
+	VirtualScanCode uint16
+	UnicodeChar     uint16
+	ControlKeyState uint32
+}
+
+// inputRecord mirrors Windows' INPUT_RECORD. Only EventType and the
+// KEY_EVENT_RECORD bytes of the union are read; other event types (mouse,
+// focus, buffer resize) are skipped.
+type inputRecord struct {
+	EventType uint16
+	_         uint16 // alignment padding before the union
+	Event     [16]byte
+}
+
+// setupTerminal enables the Windows console equivalent of alt-screen and
+// raw mode: ENABLE_VIRTUAL_TERMINAL_PROCESSING on stdout so the existing
+// ANSI escape codes (ansiClearScreen, theme colors, ...) keep rendering,
+// and ENABLE_VIRTUAL_TERMINAL_INPUT on stdin so the console translates
+// arrows, backspace, and enter into the same byte sequences
+// startKeyboardReader's Unix counterpart (keyboard_unix.go) already parses,
+// rather than delivering them as raw KEY_EVENT_RECORD fields. Returns a
+// restore function that must be deferred.
+func setupTerminal() (func(), error) {
+	stdinHandle := windows.Handle(os.Stdin.Fd())
+	stdoutHandle := windows.Handle(os.Stdout.Fd())
+
+	var oldInputMode, oldOutputMode uint32
+	if err := windows.GetConsoleMode(stdinHandle, &oldInputMode); err != nil {
+		return nil, fmt.Errorf("failed to get console input mode: %w", err)
+	}
+	if err := windows.GetConsoleMode(stdoutHandle, &oldOutputMode); err != nil {
+		return nil, fmt.Errorf("failed to get console output mode: %w", err)
+	}
+
+	newInputMode := (oldInputMode &^ (windows.ENABLE_LINE_INPUT | windows.ENABLE_ECHO_INPUT | windows.ENABLE_PROCESSED_INPUT)) | windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	if err := windows.SetConsoleMode(stdinHandle, newInputMode); err != nil {
+		return nil, fmt.Errorf("failed to set console input mode: %w", err)
+	}
+
+	newOutputMode := oldOutputMode | windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	if err := windows.SetConsoleMode(stdoutHandle, newOutputMode); err != nil {
+		_ = windows.SetConsoleMode(stdinHandle, oldInputMode)
+		return nil, fmt.Errorf("failed to set console output mode: %w", err)
+	}
+
+	fmt.Print(ansiAltScreenEnable + ansiCursorHide + ansiBracketedPasteOn)
+
+	restore := func() {
+		fmt.Print(ansiBracketedPasteOff + ansiCursorShow + ansiAltScreenDisable)
+		_ = windows.SetConsoleMode(stdinHandle, oldInputMode)
+		_ = windows.SetConsoleMode(stdoutHandle, oldOutputMode)
+		fmt.Print("\r\n")
+	}
+
+	return restore, nil
+}
+
+// startKeyboardReader spawns goroutines that read console input records via
+// ReadConsoleInputW, forwarding each key-down event's character through the
+// same control-byte/rune classification startKeyboardReader's Unix
+// counterpart applies to raw stdin bytes.
+func startKeyboardReader() <-chan keyEvent {
+	rawBytes := make(chan byte)
+	events := make(chan keyEvent)
+	stdinHandle := windows.Handle(os.Stdin.Fd())
+
+	go func() {
+		defer close(rawBytes)
+		var records [128]inputRecord
+		for {
+			var read uint32
+			ret, _, err := procReadConsoleInputW.Call(
+				uintptr(stdinHandle),
+				uintptr(unsafe.Pointer(&records[0])),
+				uintptr(len(records)),
+				uintptr(unsafe.Pointer(&read)),
+			)
+			if ret == 0 {
+				fmt.Fprintf(os.Stderr, "warning: ReadConsoleInput failed: %v\n", err)
+				return
+			}
+
+			for _, record := range records[:read] {
+				if record.EventType != keyEventType {
+					continue
+				}
+				key := (*keyEventRecord)(unsafe.Pointer(&record.Event[0]))
+				if key.KeyDown == 0 || key.UnicodeChar == 0 {
+					continue
+				}
+				for _, b := range utf16UnitToUTF8(key.UnicodeChar) {
+					rawBytes <- b
+				}
+			}
+		}
+	}()
+
+	go func() {
+		for b := range rawBytes {
+			switch {
+			case isControlByte(b):
+				events <- keyEvent{Control: b, IsCtrl: true}
+			case b < utf8.RuneSelf:
+				events <- keyEvent{Rune: rune(b)}
+			default:
+				events <- keyEvent{Rune: decodeMultibyteRune(b, rawBytes)}
+			}
+		}
+	}()
+
+	return events
+}
+
+// utf16UnitToUTF8 re-encodes a single UTF-16 code unit, as delivered by
+// KEY_EVENT_RECORD.UnicodeChar, to UTF-8 bytes. Virtual-terminal input
+// already reduces arrows, backspace, and enter to single ASCII control
+// bytes, so the common case is one code unit in, one byte out; a lone
+// surrogate half (an astral character split across two key events) decodes
+// to the replacement character instead of being reassembled, which only
+// affects characters outside the Basic Multilingual Plane.
+func utf16UnitToUTF8(unit uint16) []byte {
+	r := utf16.Decode([]uint16{unit})[0]
+	return []byte(string(r))
+}
@@ -38,14 +38,34 @@ func run() error {
 	}
 
 	keyChan := startKeyboardReader()
+	resizeChan := startResizeWatcher()
+	history := &commandHistory{}
 
 	for {
+		if config.TextSource == TextSourceCustom {
+			theme, err := loadTheme(config.ThemeName)
+			if err != nil {
+				theme = defaultTheme()
+			}
+			text, cancelled, err := promptForCustomText(keyChan, theme)
+			if err != nil {
+				return fmt.Errorf("failed to read custom text: %w", err)
+			}
+			if cancelled {
+				// Nothing to practice on; fall back to the default source
+				// rather than re-prompting forever.
+				config.TextSource = defaultTextSource
+			} else {
+				config.CustomText = text
+			}
+		}
+
 		target, err := getSessionText(config)
 		if err != nil {
 			return fmt.Errorf("failed to load session text: %w", err)
 		}
 
-		if !runSessionLoop(target, config, termWidth, keyChan) {
+		if !runSessionLoop(target, &config, &termWidth, keyChan, resizeChan, history) {
 			return nil
 		}
 	}
@@ -53,16 +73,23 @@ func run() error {
 
 // runSessionLoop runs typing sessions with the given target text until the user
 // wants new text or exits. Returns true if user wants next text, false if exiting.
-func runSessionLoop(target string, config Config, termWidth int, keyChan <-chan byte) bool {
+// config is shared across sessions so REPL commands (mode, source, theme) can
+// mutate it without restarting the program. termWidth is shared the same way:
+// a session that lives through a resize updates state.terminalWidth, and that
+// state is discarded the moment the session ends, so the latest width is
+// written back through the pointer for the next retry/next to pick up instead
+// of reverting to the width captured at startup.
+func runSessionLoop(target string, config *Config, termWidth *int, keyChan <-chan keyEvent, resizeChan <-chan struct{}, history *commandHistory) bool {
 	for {
-		state := newTypingState(target, config, termWidth)
-		action := runTypingSession(state, keyChan)
+		state := newTypingState(target, *config, *termWidth)
+		action := runTypingSession(state, keyChan, resizeChan)
+		*termWidth = state.terminalWidth
 
 		if action == ActionInterrupt {
 			return false
 		}
 
-		action = promptToContinue(keyChan)
+		action = runCommandPrompt(keyChan, config, history)
 
 		switch action {
 		case ActionExit:
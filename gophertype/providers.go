@@ -0,0 +1,214 @@
+package main
+
+import (
+	_ "embed" // Used for embedding the quote and code-snippet corpora at compile time
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+// TextProvider generates practice text for a session. Implementations are
+// registered against a TextSource in init() so getSessionText can dispatch
+// without a growing switch statement.
+type TextProvider interface {
+	// Name returns the provider's TextSource identifier.
+	Name() string
+	// Generate produces practice text for the given configuration.
+	Generate(config Config) (string, error)
+	// SupportsInfinite reports whether the provider can extend its text
+	// indefinitely, which timed mode relies on.
+	SupportsInfinite() bool
+}
+
+// providerRegistry maps a TextSource to the provider that serves it.
+var providerRegistry = map[TextSource]TextProvider{}
+
+// registerProvider adds a provider to the registry, keyed by its own Name().
+// Call from init() to make a new source available without touching
+// getSessionText.
+func registerProvider(provider TextProvider) {
+	providerRegistry[TextSource(provider.Name())] = provider
+}
+
+func init() {
+	registerProvider(wordsProvider{})
+	registerProvider(sentencesProvider{})
+	registerProvider(weakProvider{})
+	registerProvider(quotesProvider{})
+	registerProvider(codeProvider{})
+	registerProvider(fileProvider{})
+}
+
+// wordsProvider selects random words from the embedded top-1000-words list.
+type wordsProvider struct{}
+
+func (wordsProvider) Name() string          { return string(TextSourceWords) }
+func (wordsProvider) SupportsInfinite() bool { return true }
+
+func (wordsProvider) Generate(config Config) (string, error) {
+	if config.TimeSeconds > 0 {
+		return generateInfiniteWordText()
+	}
+	return generateWordText(config.WordCount)
+}
+
+// sentencesProvider selects from a small set of pangram sentences.
+type sentencesProvider struct{}
+
+func (sentencesProvider) Name() string          { return string(TextSourceSentences) }
+func (sentencesProvider) SupportsInfinite() bool { return true }
+
+func (sentencesProvider) Generate(config Config) (string, error) {
+	if config.TimeSeconds > 0 {
+		return generateInfiniteSentences(), nil
+	}
+	sentences := defaultSentences()
+	return sentences[rand.Intn(len(sentences))], nil
+}
+
+// weakProvider biases word selection towards the user's historically
+// slowest characters (see heatmap.go).
+type weakProvider struct{}
+
+func (weakProvider) Name() string          { return string(TextSourceWeak) }
+func (weakProvider) SupportsInfinite() bool { return false }
+
+func (weakProvider) Generate(config Config) (string, error) {
+	return generateWeakWordText(config.WordCount)
+}
+
+//go:embed data/quotes.txt
+var quotesData string
+
+// quotesProvider ships an embedded quote corpus segmented into short,
+// medium, and long buckets, selected via Config.QuoteLength.
+type quotesProvider struct{}
+
+func (quotesProvider) Name() string          { return string(TextSourceQuotes) }
+func (quotesProvider) SupportsInfinite() bool { return false }
+
+func (quotesProvider) Generate(config Config) (string, error) {
+	buckets := parseQuotes(quotesData)
+
+	bucket := config.QuoteLength
+	if bucket == "" {
+		bucket = defaultQuoteLength
+	}
+
+	quotes, ok := buckets[bucket]
+	if !ok || len(quotes) == 0 {
+		return "", fmt.Errorf("no quotes available for length %q", bucket)
+	}
+
+	return quotes[rand.Intn(len(quotes))], nil
+}
+
+// parseQuotes parses lines of the form "bucket|quote text" into buckets.
+func parseQuotes(data string) map[string][]string {
+	buckets := make(map[string][]string)
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		bucket, quote, found := strings.Cut(line, "|")
+		if !found {
+			continue
+		}
+
+		buckets[bucket] = append(buckets[bucket], quote)
+	}
+
+	return buckets
+}
+
+//go:embed data/snippets_go.txt
+var snippetsGo string
+
+//go:embed data/snippets_python.txt
+var snippetsPython string
+
+//go:embed data/snippets_js.txt
+var snippetsJS string
+
+// codeProvider ships embedded punctuation-heavy code snippets for Go,
+// Python, and JS, selected via Config.CodeLang.
+type codeProvider struct{}
+
+func (codeProvider) Name() string          { return string(TextSourceCode) }
+func (codeProvider) SupportsInfinite() bool { return false }
+
+func (codeProvider) Generate(config Config) (string, error) {
+	lang := config.CodeLang
+	if lang == "" {
+		lang = defaultCodeLang
+	}
+
+	var data string
+	switch lang {
+	case "go":
+		data = snippetsGo
+	case "python":
+		data = snippetsPython
+	case "js":
+		data = snippetsJS
+	default:
+		return "", fmt.Errorf("unknown code language: %s", lang)
+	}
+
+	snippets := splitSnippets(data)
+	if len(snippets) == 0 {
+		return "", fmt.Errorf("no code snippets available for %s", lang)
+	}
+
+	return snippets[rand.Intn(len(snippets))], nil
+}
+
+// splitSnippets splits an embedded corpus on blank lines into individual
+// snippets. Each snippet is flattened to a single line: internal newlines
+// and tabs become single spaces, and runs of whitespace are collapsed to
+// one. The rest of the pipeline (word wrapping, keystroke handling) only
+// understands space-separated words, not embedded line breaks - indentation
+// and line structure aren't practice-relevant here anyway, so flattening
+// keeps snippets typeable like every other text source instead of teaching
+// every layer about a new line-break token.
+func splitSnippets(data string) []string {
+	var snippets []string
+
+	for _, block := range strings.Split(data, "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		snippets = append(snippets, strings.Join(strings.Fields(block), " "))
+	}
+
+	return snippets
+}
+
+// fileProvider reads the whole of a user-supplied file as practice text.
+type fileProvider struct{}
+
+func (fileProvider) Name() string          { return string(TextSourceFile) }
+func (fileProvider) SupportsInfinite() bool { return false }
+
+func (fileProvider) Generate(config Config) (string, error) {
+	if config.FilePath == "" {
+		return "", fmt.Errorf("--path is required for --source file")
+	}
+
+	content, err := os.ReadFile(config.FilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", config.FilePath, err)
+	}
+
+	text := strings.TrimSpace(string(content))
+	if text == "" {
+		return "", fmt.Errorf("%s is empty", config.FilePath)
+	}
+
+	return text, nil
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// replCommands lists the commands recognized at the zootype> prompt, used
+// for tab-completion.
+var replCommands = []string{"retry", "next", "mode", "source", "stats", "theme", "quit"}
+
+// commandHistory tracks previously entered commands for up/down recall and
+// ctrl-r reverse search across prompts within a run.
+type commandHistory struct {
+	entries []string
+}
+
+func (h *commandHistory) add(cmd string) {
+	if cmd == "" {
+		return
+	}
+	h.entries = append(h.entries, cmd)
+}
+
+// runCommandPrompt renders the zootype> prompt, reads one command line with
+// readline-style editing, and mutates config in place based on the parsed
+// command. It returns the loop action to take (ActionRetry/ActionNext/
+// ActionExit) once a terminal command is entered; non-terminal commands
+// (mode, source, theme, stats) print feedback and loop for another command.
+func runCommandPrompt(keyChan <-chan keyEvent, config *Config, history *commandHistory) int {
+	// drain any buffered keypresses to prevent accidental triggering post-test.
+	drainChannel(keyChan, inputDrainTimeout)
+
+	theme, err := loadTheme(config.ThemeName)
+	if err != nil {
+		theme = defaultTheme()
+	}
+
+	for {
+		cmd, interrupted := promptForCommand(keyChan, history, theme)
+		if interrupted {
+			return ActionExit
+		}
+
+		history.add(cmd)
+
+		action, msg := dispatchCommand(cmd, config)
+		if msg != "" {
+			fmt.Printf("%s\r\n", msg)
+		}
+		if action != actionNone {
+			return action
+		}
+	}
+}
+
+// actionNone signals that a command was handled in place (e.g. a config
+// mutation) and the prompt should be shown again rather than returning.
+const actionNone = -1
+
+// promptForCommand reads a single line at the zootype> prompt, supporting
+// left/right/home/end cursor movement, backspace/delete, up/down history
+// recall, ctrl-r reverse search, and tab-completion of command names.
+func promptForCommand(keyChan <-chan keyEvent, history *commandHistory, theme Theme) (string, bool) {
+	var line []rune
+	cursor := 0
+	historyPos := len(history.entries)
+
+	render := func() {
+		fmt.Print("\r" + ansiClearToEOL)
+		fmt.Printf("%szootype>%s %s", theme.Blue, theme.Reset, string(line))
+		if back := len(line) - cursor; back > 0 {
+			fmt.Print(strings.Repeat("\b", back))
+		}
+	}
+	render()
+
+	for {
+		key := <-keyChan
+
+		if key.IsCtrl {
+			switch key.Control {
+			case keyCtrlC:
+				fmt.Print("\r\n")
+				return "", true
+
+			case keyEnter, keyReturn:
+				fmt.Print("\r\n")
+				return string(line), false
+
+			case keyDelete, keyBackspace:
+				if cursor > 0 {
+					line = append(line[:cursor-1], line[cursor:]...)
+					cursor--
+					render()
+				}
+
+			case keyEscape:
+				switch readCSIKey(keyChan) {
+				case csiUp:
+					if historyPos > 0 {
+						historyPos--
+						line = []rune(history.entries[historyPos])
+						cursor = len(line)
+						render()
+					}
+				case csiDown:
+					if historyPos < len(history.entries)-1 {
+						historyPos++
+						line = []rune(history.entries[historyPos])
+					} else {
+						historyPos = len(history.entries)
+						line = nil
+					}
+					cursor = len(line)
+					render()
+				case csiLeft:
+					if cursor > 0 {
+						cursor--
+						render()
+					}
+				case csiRight:
+					if cursor < len(line) {
+						cursor++
+						render()
+					}
+				case csiHome:
+					cursor = 0
+					render()
+				case csiEnd:
+					cursor = len(line)
+					render()
+				case csiDelete:
+					if cursor < len(line) {
+						line = append(line[:cursor], line[cursor+1:]...)
+						render()
+					}
+				}
+			}
+			continue
+		}
+
+		switch key.Rune {
+		case 18: // Ctrl-R: reverse-search over prior commands
+			if match, ok := reverseSearchCommand(keyChan, history, theme); ok {
+				line = []rune(match)
+				cursor = len(line)
+			}
+			render()
+
+		case '\t':
+			line, cursor = completeCommand(line, cursor)
+			render()
+
+		default:
+			if key.Rune >= 32 {
+				line = append(line[:cursor], append([]rune{key.Rune}, line[cursor:]...)...)
+				cursor++
+				render()
+			}
+		}
+	}
+}
+
+// reverseSearchCommand implements a minimal ctrl-r: each typed character
+// narrows the search query and the most recent matching command from
+// history is shown; Enter accepts it, Esc/Ctrl-C cancels the search.
+func reverseSearchCommand(keyChan <-chan keyEvent, history *commandHistory, theme Theme) (string, bool) {
+	query := ""
+	fmt.Printf("\r%s(reverse-search)%s `` ", theme.Yellow, theme.Reset)
+
+	for {
+		key := <-keyChan
+
+		if key.IsCtrl {
+			switch key.Control {
+			case keyEnter, keyReturn:
+				return findMostRecentMatch(history, query)
+			case keyEscape, keyCtrlC:
+				return "", false
+			case keyDelete, keyBackspace:
+				if len(query) > 0 {
+					query = query[:len(query)-1]
+				}
+			}
+		} else if key.Rune >= 32 {
+			query += string(key.Rune)
+		}
+
+		match, _ := findMostRecentMatch(history, query)
+		fmt.Printf("\r%s(reverse-search)%s `%s` %s", theme.Yellow, theme.Reset, query, match)
+	}
+}
+
+func findMostRecentMatch(history *commandHistory, query string) (string, bool) {
+	if query == "" {
+		return "", false
+	}
+	for i := len(history.entries) - 1; i >= 0; i-- {
+		if strings.Contains(history.entries[i], query) {
+			return history.entries[i], true
+		}
+	}
+	return "", false
+}
+
+// completeCommand tab-completes the command word at the start of the line
+// against replCommands.
+func completeCommand(line []rune, cursor int) ([]rune, int) {
+	fields := strings.Fields(string(line[:cursor]))
+	if len(fields) != 1 || strings.HasSuffix(string(line[:cursor]), " ") {
+		return line, cursor
+	}
+
+	prefix := fields[0]
+	var match string
+	for _, cmd := range replCommands {
+		if strings.HasPrefix(cmd, prefix) {
+			if match != "" {
+				return line, cursor // ambiguous
+			}
+			match = cmd
+		}
+	}
+	if match == "" {
+		return line, cursor
+	}
+
+	rest := line[cursor:]
+	completed := append([]rune(match), rest...)
+	return completed, len(match)
+}
+
+// dispatchCommand parses and applies a single REPL command line, mutating
+// config for commands that change session settings. It returns actionNone
+// for commands handled in place, or the loop action for retry/next/quit.
+func dispatchCommand(cmd string, config *Config) (int, string) {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return actionNone, ""
+	}
+
+	switch fields[0] {
+	case "retry":
+		return ActionRetry, ""
+	case "next":
+		return ActionNext, ""
+	case "quit", "exit":
+		return ActionExit, ""
+	case "mode":
+		return actionNone, applyModeCommand(fields[1:], config)
+	case "source":
+		return actionNone, applySourceCommand(fields[1:], config)
+	case "theme":
+		return actionNone, applyThemeCommand(fields[1:], config)
+	case "stats":
+		theme, err := loadTheme(config.ThemeName)
+		if err != nil {
+			theme = defaultTheme()
+		}
+		if err := printHistoryStats(theme); err != nil {
+			return actionNone, fmt.Sprintf("error: %v", err)
+		}
+		return actionNone, ""
+	default:
+		return actionNone, fmt.Sprintf("unknown command: %s", fields[0])
+	}
+}
+
+func applyModeCommand(args []string, config *Config) string {
+	if len(args) != 2 {
+		return "usage: mode time <seconds> | mode words <count>"
+	}
+
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n <= 0 {
+		return "invalid count: " + args[1]
+	}
+
+	switch args[0] {
+	case "time":
+		config.TimeSeconds = n
+		config.WordCount = 0
+		return fmt.Sprintf("mode set to %ds timed", n)
+	case "words":
+		config.WordCount = n
+		config.TimeSeconds = 0
+		return fmt.Sprintf("mode set to %d words", n)
+	default:
+		return "usage: mode time <seconds> | mode words <count>"
+	}
+}
+
+func applySourceCommand(args []string, config *Config) string {
+	if len(args) != 1 {
+		return "usage: source <words|sentences|weak|quotes|code|file|custom>"
+	}
+
+	source := TextSource(args[0])
+	if _, ok := providerRegistry[source]; !ok && source != TextSourceCustom {
+		return "unknown source: " + args[0]
+	}
+
+	config.TextSource = source
+	return "source set to " + args[0]
+}
+
+func applyThemeCommand(args []string, config *Config) string {
+	if len(args) != 1 {
+		return "usage: theme <name>"
+	}
+	config.ThemeName = args[0]
+	return "theme set to " + args[0]
+}
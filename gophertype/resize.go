@@ -0,0 +1,39 @@
+package main
+
+import "time"
+
+// resizeDebounce collapses a burst of resize notifications (the terminal
+// typically fires several as the user drags a window edge) into a single
+// repaint once the size settles.
+const resizeDebounce = 50 * time.Millisecond
+
+// startResizeWatcher installs a platform-specific resize notification
+// (SIGWINCH where available, a polling fallback otherwise, see
+// watchResize) and returns a debounced channel that fires once after each
+// burst of resizes.
+func startResizeWatcher() <-chan struct{} {
+	raw := make(chan struct{}, 1)
+	watchResize(raw)
+
+	debounced := make(chan struct{})
+	go func() {
+		for range raw {
+			timer := time.NewTimer(resizeDebounce)
+		drain:
+			for {
+				select {
+				case <-raw:
+					if !timer.Stop() {
+						<-timer.C
+					}
+					timer.Reset(resizeDebounce)
+				case <-timer.C:
+					break drain
+				}
+			}
+			debounced <- struct{}{}
+		}
+	}()
+
+	return debounced
+}
@@ -0,0 +1,42 @@
+//go:build windows || plan9
+
+package main
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// resizePollInterval is how often we check the terminal size on platforms
+// with no SIGWINCH equivalent to notify us of a resize.
+const resizePollInterval = 500 * time.Millisecond
+
+// watchResize polls the terminal size on platforms without SIGWINCH
+// support, notifying ch whenever the width or height changes.
+func watchResize(ch chan<- struct{}) {
+	go func() {
+		width, height, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			width, height = 0, 0
+		}
+
+		ticker := time.NewTicker(resizePollInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			w, h, err := term.GetSize(int(os.Stdout.Fd()))
+			if err != nil {
+				continue
+			}
+			if w != width || h != height {
+				width, height = w, h
+				select {
+				case ch <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+}
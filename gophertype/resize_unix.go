@@ -0,0 +1,24 @@
+//go:build !windows && !plan9
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize notifies ch whenever the terminal receives SIGWINCH.
+func watchResize(ch chan<- struct{}) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGWINCH)
+
+	go func() {
+		for range sigCh {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}()
+}
@@ -2,11 +2,10 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 	"os"
-	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/term"
 )
@@ -17,30 +16,25 @@ const (
 	keyEnter     = 10 // LF - Unix/Mac enter
 	keyReturn    = 13 // CR - Windows enter
 	keyEscape    = 27
-	keyUpperN    = 78
-	keyUpperQ    = 81
-	keyUpperR    = 82
-	keyLowerN    = 110
-	keyLowerQ    = 113
-	keyLowerR    = 114
 	keyDelete    = 127 // DEL - commonly sent by backspace on Unix/Mac
 
-	textExtensionWords = 100
-	escapeSeqTimeout   = 10 * time.Millisecond
-	inputDrainTimeout  = 500 * time.Millisecond
+	escapeSeqTimeout  = 10 * time.Millisecond
+	inputDrainTimeout = 500 * time.Millisecond
 )
 
 // TypingState tracks both corrected metrics (accounting for backspaces)
 // and raw metrics (all keystrokes) for calculating accuracy statistics.
 type TypingState struct {
-	sessionText     string
-	position        int    // Current cursor position in sessionText
+	config          Config
+	sessionText     []rune
+	originalText    []rune // The provider's initial output, fixed; repeated by extendTextForTimedMode for non-infinite providers
+	position        int    // Current cursor position in sessionText, in runes
 	errors          int    // Decremented on backspace correction
 	rawErrors       int    // Never decremented (for raw accuracy)
 	charsTyped      int    // Decremented on backspace
 	rawKeystrokes   int    // Never decremented
-	charCorrectness []bool // Per-character correctness for coloring
-	charTyped       []bool // Tracks which chars were actually typed (not skipped)
+	charCorrectness []bool // Per-rune correctness for coloring
+	charTyped       []bool // Tracks which runes were actually typed (not skipped)
 	backspaceCount  int
 	startTime       time.Time
 	lastLineCount   int           // Lines in previous display (for clearing)
@@ -48,6 +42,25 @@ type TypingState struct {
 	isTimedMode     bool
 	displayMutex    sync.Mutex // Synchronizes display updates
 	terminalWidth   int
+	keystrokes      []keystrokeEvent // Timestamped log for heatmap/dwell analysis
+	samples         []progressSample // ~1Hz snapshots for the post-session graph
+	lastSampleTime  time.Time
+	theme           Theme
+}
+
+// progressSample is a ~1Hz snapshot of session progress, used to render the
+// post-session WPM/accuracy graph.
+type progressSample struct {
+	elapsed      time.Duration
+	correctChars int
+	errors       int
+}
+
+// keystrokeEvent records when a character was typed, for per-key and
+// per-bigram dwell-time analysis.
+type keystrokeEvent struct {
+	char rune
+	at   time.Time
 }
 
 // newTypingState initializes a new typing session with the given target text.
@@ -58,38 +71,28 @@ func newTypingState(target string, config Config, termWidth int) *TypingState {
 		timeLimit = time.Duration(config.TimeSeconds) * time.Second
 	}
 
+	theme, err := loadTheme(config.ThemeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v, falling back to default theme\n", err)
+		theme = defaultTheme()
+	}
+
+	runes := []rune(target)
+
 	return &TypingState{
-		sessionText:     target,
-		charCorrectness: make([]bool, len(target)),
-		charTyped:       make([]bool, len(target)),
+		config:          config,
+		sessionText:     runes,
+		originalText:    runes,
+		charCorrectness: make([]bool, len(runes)),
+		charTyped:       make([]bool, len(runes)),
 		startTime:       time.Time{},
 		timeLimit:       timeLimit,
 		isTimedMode:     isTimedMode,
 		terminalWidth:   termWidth,
+		theme:           theme,
 	}
 }
 
-// setupTerminal enables alternate screen buffer and raw mode for character-by-character input.
-// Returns a restore function that must be deferred.
-func setupTerminal() (func(), error) {
-	fmt.Print(ansiAltScreenEnable + ansiCursorHide)
-
-	stdinFd := int(os.Stdin.Fd())
-	oldState, err := term.MakeRaw(stdinFd)
-	if err != nil {
-		fmt.Print(ansiCursorShow + ansiAltScreenDisable)
-		return nil, err
-	}
-
-	restore := func() {
-		_ = term.Restore(stdinFd, oldState)
-		fmt.Print(ansiCursorShow + ansiAltScreenDisable)
-		fmt.Print("\r\n")
-	}
-
-	return restore, nil
-}
-
 // getTerminalWidth returns the current terminal width.
 func getTerminalWidth() (int, error) {
 	width, _, err := term.GetSize(int(os.Stdout.Fd()))
@@ -99,44 +102,91 @@ func getTerminalWidth() (int, error) {
 	return width, nil
 }
 
+// Terminal width bounds enforced both at startup and on resize.
+const (
+	minTerminalWidth = 25
+	maxTerminalWidth = 80
+)
+
 // getAndValidateTerminalWidth validates terminal width (min 25, capped at 80).
 func getAndValidateTerminalWidth() (int, error) {
-	const (
-		minWidth = 25
-		maxWidth = 80
-	)
-
 	termWidth, err := getTerminalWidth()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get terminal size: %w", err)
 	}
 
-	if termWidth < minWidth {
-		return 0, fmt.Errorf("terminal too narrow: %d chars (minimum %d chars required)", termWidth, minWidth)
+	if termWidth < minTerminalWidth {
+		return 0, fmt.Errorf("terminal too narrow: %d chars (minimum %d chars required)", termWidth, minTerminalWidth)
 	}
 
-	if termWidth > maxWidth {
-		termWidth = maxWidth
+	if termWidth > maxTerminalWidth {
+		termWidth = maxTerminalWidth
 	}
 
 	return termWidth, nil
 }
 
-// startKeyboardReader spawns a goroutine that reads keyboard input for the duration of the program.
-func startKeyboardReader() <-chan byte {
-	keyChan := make(chan byte)
+// keyEvent is a single unit of keyboard input: either a decoded rune
+// (printable text, including multi-byte UTF-8 characters) or a recognized
+// control byte (Ctrl-C, backspace, ESC, etc.) that doesn't decode as text.
+type keyEvent struct {
+	Rune    rune
+	Control byte
+	IsCtrl  bool
+}
 
-	go func() {
-		buf := make([]byte, 1)
-		for {
-			if _, err := os.Stdin.Read(buf); err != nil {
-				return
+func isControlByte(b byte) bool {
+	switch b {
+	case keyCtrlC, keyBackspace, keyEnter, keyReturn, keyEscape, keyDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeMultibyteRune accumulates the continuation bytes of a UTF-8
+// sequence that began with lead. A short per-byte timeout guards against a
+// malformed or truncated sequence (e.g. a lead byte with no continuation
+// bytes following) hanging the reader forever instead of surfacing
+// whatever was actually received.
+func decodeMultibyteRune(lead byte, rawBytes <-chan byte) rune {
+	size := utf8SequenceLen(lead)
+	buf := make([]byte, 1, size)
+	buf[0] = lead
+
+	for len(buf) < size {
+		select {
+		case b, ok := <-rawBytes:
+			if !ok {
+				// Channel closed (stdin EOF) mid-sequence: decode whatever
+				// was received instead of spinning on a drained channel.
+				r, _ := utf8.DecodeRune(buf)
+				return r
 			}
-			keyChan <- buf[0]
+			buf = append(buf, b)
+		case <-time.After(escapeSeqTimeout):
+			r, _ := utf8.DecodeRune(buf)
+			return r
 		}
-	}()
+	}
 
-	return keyChan
+	r, _ := utf8.DecodeRune(buf)
+	return r
+}
+
+// utf8SequenceLen returns the total byte length of the UTF-8 sequence that
+// starts with the given lead byte, per the encoding's leading-byte markers.
+func utf8SequenceLen(lead byte) int {
+	switch {
+	case lead&0xE0 == 0xC0:
+		return 2
+	case lead&0xF0 == 0xE0:
+		return 3
+	case lead&0xF8 == 0xF0:
+		return 4
+	default:
+		return 1
+	}
 }
 
 const (
@@ -146,33 +196,10 @@ const (
 	ActionInterrupt = 3
 )
 
-// promptToContinue asks the user what to do next: (n)ext, (r)etry, or (q)uit.
-func promptToContinue(keyChan <-chan byte) int {
-	// drain any buffered keypresses to prevent accidental triggering post-test.
-	drainChannel(keyChan, inputDrainTimeout)
-
-	fmt.Printf("\n%s(n)ext, (r)etry, (q)uit%s", ansiBlue, ansiReset)
-
-	for {
-		key := <-keyChan
-
-		switch key {
-		case keyCtrlC:
-			return ActionExit
-		case keyEnter, keyReturn, keyLowerN, keyUpperN:
-			return ActionNext
-		case keyLowerR, keyUpperR:
-			return ActionRetry
-		case keyLowerQ, keyUpperQ:
-			return ActionExit
-		}
-	}
-}
-
 // runTypingSession is the main event loop for a typing session.
-func runTypingSession(state *TypingState, keyChan <-chan byte) int {
+func runTypingSession(state *TypingState, keyChan <-chan keyEvent, resizeChan <-chan struct{}) int {
 	fmt.Print(ansiClearScreen + ansiCursorHome)
-	fmt.Printf("%sgophertype%s\n\n", ansiBlue, ansiReset)
+	fmt.Printf("%sgophertype%s\n\n", state.theme.Blue, state.theme.Reset)
 
 	state.startTime = time.Now()
 	timeUp := make(chan bool)
@@ -205,8 +232,13 @@ func runTypingSession(state *TypingState, keyChan <-chan byte) int {
 		// Check if we've reached the end of text (only matters in non-timed mode)
 		if !state.isTimedMode && state.position >= len(state.sessionText) {
 			fmt.Print("\r\n\r\n")
+			fmt.Print(renderSessionGraph(state))
 			results := NewResults(state)
 			results.Print()
+			recordSessionHistory(state, results)
+			if state.config.ShowHeatmap {
+				printHeatmap(state)
+			}
 			return ActionNext
 		}
 
@@ -215,30 +247,38 @@ func runTypingSession(state *TypingState, keyChan <-chan byte) int {
 			extendTextForTimedMode(state)
 		}
 
-		var key byte
+		var key keyEvent
 		select {
 		case <-timeUp:
 			fmt.Print("\r\n\r\n")
+			fmt.Print(renderSessionGraph(state))
 			results := NewResults(state)
 			results.Print()
+			recordSessionHistory(state, results)
+			if state.config.ShowHeatmap {
+				printHeatmap(state)
+			}
 			return ActionNext
+		case <-resizeChan:
+			handleResize(state)
+			continue
 		case key = <-keyChan:
 			// Process the key below
 		}
 
-		if isInterrupt(key) {
+		if key.IsCtrl && key.Control == keyCtrlC {
 			fmt.Print("\r\n\r\n")
 			results := NewResults(state)
 			results.Print()
 			return ActionInterrupt
 		}
 
-		if key == keyEscape {
-			drainEscapeSequence(keyChan)
+		if key.IsCtrl && key.Control == keyEscape {
+			handleEscapeSequence(state, keyChan)
 			continue
 		}
 
-		if isBackspace(key) {
+		if key.IsCtrl && (key.Control == keyDelete || key.Control == keyBackspace) {
 			handleBackspace(state)
 			state.displayMutex.Lock()
 			displayProgress(state)
@@ -246,34 +286,75 @@ func runTypingSession(state *TypingState, keyChan <-chan byte) int {
 			continue
 		}
 
-		handleKeystroke(state, key)
+		if key.IsCtrl {
+			// Enter/Return and any other recognized control byte have no
+			// effect on typed text mid-session.
+			continue
+		}
+
+		handleKeystroke(state, key.Rune)
 		state.displayMutex.Lock()
 		displayProgress(state)
 		state.displayMutex.Unlock()
 	}
 }
 
-func isInterrupt(key byte) bool {
-	return key == keyCtrlC
+// handleEscapeSequence is called once an ESC control byte arrives mid-session.
+// Most escape sequences (arrow keys, function keys) have no effect on typed
+// text and are simply discarded; the bracketed-paste start marker is
+// recognized and handed off to consumeBracketedPaste so pasted text never
+// flows through handleKeystroke as if it were typed.
+func handleEscapeSequence(state *TypingState, keyChan <-chan keyEvent) {
+	if readCSIKey(keyChan) == csiPasteStart {
+		consumeBracketedPaste(state, keyChan)
+	}
 }
 
-// drainEscapeSequence consumes escape sequence bytes (arrow keys, function keys, etc).
-// Most sequences are 2-3 bytes (ESC [ X), but some can be longer.
-func drainEscapeSequence(keyChan <-chan byte) {
-	const maxEscapeLen = 10
-	timeout := time.After(escapeSeqTimeout)
+// consumeBracketedPaste drains events until the bracketed-paste end marker
+// (CSI 201~), keeping pasted bytes out of handleKeystroke so they can't
+// inflate WPM or corrupt accuracy stats. Depending on config.PasteMode it
+// either drops the paste or counts every pasted rune as a raw error, and
+// in both modes flashes a "paste ignored" warning; either way the time
+// spent draining the paste is excluded from the
+// session clock so it doesn't depress the resulting WPM.
+func consumeBracketedPaste(state *TypingState, keyChan <-chan keyEvent) {
+	pasteStart := time.Now()
+	pastedChars := 0
 
-	for i := 0; i < maxEscapeLen; i++ {
-		select {
-		case <-keyChan:
-		case <-timeout:
-			return
+	for {
+		key := <-keyChan
+		if key.IsCtrl && key.Control == keyEscape {
+			if readCSIKey(keyChan) == csiPasteEnd {
+				break
+			}
+			continue
 		}
+		if !key.IsCtrl {
+			pastedChars++
+		}
+	}
+
+	elapsed := time.Since(pasteStart)
+	msg := "paste ignored"
+
+	state.displayMutex.Lock()
+	if state.config.PasteMode == pasteModeCountErrors {
+		state.rawKeystrokes += pastedChars
+		state.rawErrors += pastedChars
+		msg = fmt.Sprintf("paste ignored (%d chars counted as errors)", pastedChars)
+	}
+	if !state.startTime.IsZero() {
+		state.startTime = state.startTime.Add(elapsed)
 	}
+	fmt.Print(buildClearSequence(state.lastLineCount))
+	state.lastLineCount = 0
+	fmt.Printf("%s%s%s\r\n", state.theme.Yellow, msg, state.theme.Reset)
+	displayProgress(state)
+	state.displayMutex.Unlock()
 }
 
 // drainChannel consumes buffered keypresses to prevent accidental input.
-func drainChannel(keyChan <-chan byte, timeout time.Duration) {
+func drainChannel(keyChan <-chan keyEvent, timeout time.Duration) {
 	deadline := time.After(timeout)
 	for {
 		select {
@@ -284,8 +365,27 @@ func drainChannel(keyChan <-chan byte, timeout time.Duration) {
 	}
 }
 
-func isBackspace(key byte) bool {
-	return key == keyDelete || key == keyBackspace
+// handleResize re-measures the terminal after a resize notification, clamps
+// it to the 25/80 bounds enforced at startup, and forces a full redraw so
+// the display re-wraps at the new width instead of drifting out of sync.
+func handleResize(state *TypingState) {
+	width, err := getTerminalWidth()
+	if err != nil {
+		return
+	}
+
+	if width < minTerminalWidth {
+		width = minTerminalWidth
+	} else if width > maxTerminalWidth {
+		width = maxTerminalWidth
+	}
+
+	state.displayMutex.Lock()
+	fmt.Print(buildClearSequence(state.lastLineCount))
+	state.terminalWidth = width
+	state.lastLineCount = 0
+	displayProgress(state)
+	state.displayMutex.Unlock()
 }
 
 // handleBackspace moves cursor back and updates metrics.
@@ -308,12 +408,12 @@ func handleBackspace(state *TypingState) {
 }
 
 // handleKeystroke processes character input and updates correctness tracking.
-func handleKeystroke(state *TypingState, key byte) {
-	char := string(key)
+func handleKeystroke(state *TypingState, r rune) {
 	state.charsTyped++
 	state.rawKeystrokes++
+	state.keystrokes = append(state.keystrokes, keystrokeEvent{char: r, at: time.Now()})
 
-	if char == " " {
+	if r == ' ' {
 		handleSpace(state)
 		return
 	}
@@ -323,7 +423,7 @@ func handleKeystroke(state *TypingState, key byte) {
 	}
 
 	state.charTyped[state.position] = true
-	if char == string(state.sessionText[state.position]) {
+	if r == state.sessionText[state.position] {
 		state.charCorrectness[state.position] = true
 	} else {
 		state.charCorrectness[state.position] = false
@@ -377,20 +477,28 @@ func markRangeIncorrect(state *TypingState, start, end int) {
 	}
 }
 
-// extendTextForTimedMode appends more words when the user reaches the end in timed mode.
+// extendTextForTimedMode appends more text once the user reaches the end of
+// the current session text in timed mode. Providers that support
+// indefinite generation (see TextProvider.SupportsInfinite) are asked for
+// another chunk via the registry; providers that don't (quotes, code,
+// weak, file, custom) have no further corpus to draw from, so the original
+// fixed passage (state.originalText) is repeated instead of silently
+// falling back to unrelated random words. Repeating originalText rather
+// than the ever-growing state.sessionText keeps each extension the same
+// size instead of doubling it.
 func extendTextForTimedMode(state *TypingState) {
-	words, err := loadTopWords()
-	if err != nil {
-		return
-	}
+	chunk := string(state.originalText)
 
-	var newWords []string
-	for i := 0; i < textExtensionWords; i++ {
-		newWords = append(newWords, words[rand.Intn(len(words))])
+	if provider, ok := providerRegistry[state.config.TextSource]; ok && provider.SupportsInfinite() {
+		text, err := provider.Generate(state.config)
+		if err != nil {
+			return
+		}
+		chunk = text
 	}
 
-	newText := " " + strings.Join(newWords, " ")
-	state.sessionText += newText
+	newText := []rune(" " + chunk)
+	state.sessionText = append(state.sessionText, newText...)
 
 	oldLen := len(state.charCorrectness)
 	newLen := oldLen + len(newText)
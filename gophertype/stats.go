@@ -8,6 +8,7 @@ import (
 const charsPerWord = 5.0
 
 type Results struct {
+	Theme          Theme
 	Duration       time.Duration
 	Accuracy       float64
 	RawAccuracy    float64
@@ -24,6 +25,7 @@ func NewResults(state *TypingState) *Results {
 	correctChars := countCorrectChars(state)
 
 	return &Results{
+		Theme:          state.theme,
 		Duration:       duration,
 		Accuracy:       calculateAccuracy(state.charsTyped, state.errors),
 		RawAccuracy:    calculateAccuracy(state.rawKeystrokes, state.rawErrors),
@@ -36,7 +38,7 @@ func NewResults(state *TypingState) *Results {
 }
 
 func (r *Results) Print() {
-	fmt.Printf("%sResults:%s\r\n", ansiBlue, ansiReset)
+	fmt.Printf("%sResults:%s\r\n", r.Theme.Blue, r.Theme.Reset)
 	fmt.Printf("WPM:          %.1f\r\n", r.WPM)
 	fmt.Printf("Duration:     %ds\r\n", int(r.Duration.Seconds()))
 	fmt.Printf("Accuracy:     %.1f%%\r\n", r.Accuracy)
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Theme abstracts the ANSI color sequences used throughout session display,
+// the REPL prompt, the post-session graph and heatmap, and the custom-text
+// editor, so the palette can be swapped without touching the rendering code
+// itself. Fields may hold either the original 8-color escape codes or 24-bit
+// truecolor sequences.
+type Theme struct {
+	Name   string
+	Red    string
+	Green  string
+	Yellow string
+	Blue   string
+	Bold   string
+	Reset  string
+}
+
+// defaultTheme reproduces the original hard-coded 8-color palette.
+func defaultTheme() Theme {
+	return Theme{
+		Name:   "default",
+		Red:    ansiRed,
+		Green:  ansiGreen,
+		Yellow: ansiYellow,
+		Blue:   ansiBlue,
+		Bold:   ansiBold,
+		Reset:  ansiReset,
+	}
+}
+
+// truecolor builds a 24-bit foreground color escape sequence.
+func truecolor(r, g, b int) string {
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+}
+
+// embeddedThemes ships a handful of popular truecolor palettes so users
+// don't need to author their own theme file to get started.
+func embeddedThemes() map[string]Theme {
+	return map[string]Theme{
+		"dracula": {
+			Name: "dracula", Red: truecolor(255, 85, 85), Green: truecolor(80, 250, 123),
+			Yellow: truecolor(241, 250, 140), Blue: truecolor(139, 233, 253), Bold: ansiBold, Reset: ansiReset,
+		},
+		"gruvbox": {
+			Name: "gruvbox", Red: truecolor(251, 73, 52), Green: truecolor(184, 187, 38),
+			Yellow: truecolor(250, 189, 47), Blue: truecolor(131, 165, 152), Bold: ansiBold, Reset: ansiReset,
+		},
+		"solarized": {
+			Name: "solarized", Red: truecolor(220, 50, 47), Green: truecolor(133, 153, 0),
+			Yellow: truecolor(181, 137, 0), Blue: truecolor(38, 139, 210), Bold: ansiBold, Reset: ansiReset,
+		},
+		"nord": {
+			Name: "nord", Red: truecolor(191, 97, 106), Green: truecolor(163, 190, 140),
+			Yellow: truecolor(235, 203, 139), Blue: truecolor(129, 161, 193), Bold: ansiBold, Reset: ansiReset,
+		},
+	}
+}
+
+// loadTheme resolves a theme by name: first against the embedded defaults,
+// then against ~/.config/zootype/themes/<name>.toml. An empty name (or
+// "default") returns defaultTheme().
+func loadTheme(name string) (Theme, error) {
+	if name == "" || name == "default" {
+		return defaultTheme(), nil
+	}
+
+	if theme, ok := embeddedThemes()[name]; ok {
+		return theme, nil
+	}
+
+	return loadThemeFile(name)
+}
+
+// loadThemeFile reads a user theme from ~/.config/zootype/themes/<name>.toml.
+// The file format is a flat set of `key = "value"` pairs: red, green,
+// yellow, blue, bold (each either an ANSI escape or left unset to fall back
+// to the default palette's color).
+func loadThemeFile(name string) (Theme, error) {
+	dir, err := zootypeConfigDir()
+	if err != nil {
+		return Theme{}, err
+	}
+
+	path := filepath.Join(dir, "themes", name+".toml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to load theme %q: %w", name, err)
+	}
+
+	theme := defaultTheme()
+	theme.Name = name
+
+	values := parseSimpleTOML(string(data))
+	if v, ok := values["red"]; ok {
+		theme.Red = v
+	}
+	if v, ok := values["green"]; ok {
+		theme.Green = v
+	}
+	if v, ok := values["yellow"]; ok {
+		theme.Yellow = v
+	}
+	if v, ok := values["blue"]; ok {
+		theme.Blue = v
+	}
+	if v, ok := values["bold"]; ok {
+		theme.Bold = v
+	}
+
+	return theme, nil
+}
+
+// zootypeConfigDir returns ~/.config/zootype, creating it if necessary.
+func zootypeConfigDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+
+	dir := filepath.Join(home, ".config", "zootype")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	return dir, nil
+}
+
+// parseSimpleTOML parses a flat subset of TOML: `key = "value"` or
+// `key = 123` pairs, one per line, ignoring blank lines, `#` comments, and
+// `[section]` headers. It's deliberately minimal - just enough for theme
+// and config files that never nest.
+func parseSimpleTOML(data string) map[string]string {
+	values := make(map[string]string)
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		value = strings.Trim(value, `"`)
+		values[key] = value
+	}
+
+	return values
+}
+
+// persistedConfig mirrors the subset of Config that can be set persistently
+// via ~/.config/zootype/config.toml, so users don't have to pass flags on
+// every invocation.
+type persistedConfig struct {
+	TextSource  TextSource
+	WordCount   int
+	TimeSeconds int
+	ThemeName   string
+}
+
+// loadPersistedConfig reads ~/.config/zootype/config.toml, returning a zero
+// value (no overrides) if the file doesn't exist.
+func loadPersistedConfig() (persistedConfig, error) {
+	var persisted persistedConfig
+
+	dir, err := zootypeConfigDir()
+	if err != nil {
+		return persisted, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.toml"))
+	if os.IsNotExist(err) {
+		return persisted, nil
+	} else if err != nil {
+		return persisted, fmt.Errorf("failed to read config.toml: %w", err)
+	}
+
+	values := parseSimpleTOML(string(data))
+
+	if v, ok := values["source"]; ok {
+		persisted.TextSource = TextSource(v)
+	}
+	if v, ok := values["words"]; ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			persisted.WordCount = n
+		}
+	}
+	if v, ok := values["time"]; ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			persisted.TimeSeconds = n
+		}
+	}
+	if v, ok := values["theme"]; ok {
+		persisted.ThemeName = v
+	}
+
+	return persisted, nil
+}